@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package proxy implements a small HTTP middleware that rewrites outbound
+// binary-mode CloudEvents' ce-source, ce-type, and extension attribute
+// headers according to overrides computed from a ContainerSource's
+// Spec.CEOverrides, before the event is forwarded on to the sink.
+//
+// It is not currently wired into the receive adapter as a sidecar container:
+// this tree has no cmd/ entrypoint that turns Handler into a runnable binary
+// and no image build for one, and the SinkBinding mutating webhook that would
+// need to route K_SINK to a sidecar instead of the user's own container isn't
+// present in this tree either, so there's no way to inject this proxy
+// transparently (without cooperation from the user's container) and verify
+// it end to end here. K_CE_OVERRIDES (see
+// pkg/reconciler/containersource/resources/ceoverrides.go) remains the
+// integration point until that changes.
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	ceSourceHeader    = "Ce-Source"
+	ceTypeHeader      = "Ce-Type"
+	ceExtensionPrefix = "Ce-"
+)
+
+// Overrides is the set of CloudEvents context attribute overrides the proxy applies
+// to every event it forwards. It is the wire format of K_CE_OVERRIDES, matching
+// sourcesv1.ContainerSourceCloudEventOverrides.
+type Overrides struct {
+	Source     string            `json:"source,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Extensions map[string]string `json:"extensions,omitempty"`
+}
+
+// ParseOverrides decodes the K_CE_OVERRIDES env var value into an Overrides.
+func ParseOverrides(env string) (Overrides, error) {
+	if env == "" {
+		return Overrides{}, nil
+	}
+	var o Overrides
+	if err := json.Unmarshal([]byte(env), &o); err != nil {
+		return Overrides{}, err
+	}
+	return o, nil
+}
+
+// Handler wraps an http.Handler that forwards CloudEvents on to the ContainerSource's
+// sink, rewriting each intercepted event's binary-mode context headers according to
+// Overrides before forwarding the request.
+type Handler struct {
+	next      http.Handler
+	overrides Overrides
+}
+
+// NewHandler returns a Handler that applies overrides to every event it proxies to next.
+func NewHandler(next http.Handler, overrides Overrides) *Handler {
+	return &Handler{next: next, overrides: overrides}
+}
+
+// ServeHTTP implements http.Handler. Only requests carrying binary-mode CloudEvents
+// (identified by the Ce-Id header) are rewritten; anything else is forwarded unmodified.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Ce-Id") == "" {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+
+	if h.overrides.Source != "" {
+		req.Header.Set(ceSourceHeader, h.overrides.Source)
+	}
+	if h.overrides.Type != "" {
+		req.Header.Set(ceTypeHeader, h.overrides.Type)
+	}
+	for k, v := range h.overrides.Extensions {
+		// Extension keys are attacker- and user-controlled (they come straight from
+		// Spec.CEOverrides.Extensions); guard against the empty key instead of
+		// panicking on k[:1].
+		if k == "" {
+			continue
+		}
+		header := ceExtensionPrefix + strings.ToUpper(k[:1]) + k[1:]
+		if v == "" {
+			req.Header.Del(header)
+			continue
+		}
+		req.Header.Set(header, v)
+	}
+
+	h.next.ServeHTTP(w, req)
+}
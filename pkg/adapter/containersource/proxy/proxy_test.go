@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		want    Overrides
+		wantErr bool
+	}{{
+		name: "empty env",
+		env:  "",
+		want: Overrides{},
+	}, {
+		name: "source, type, and extensions",
+		env:  `{"source":"my-source","type":"my.type","extensions":{"foo":"bar"}}`,
+		want: Overrides{Source: "my-source", Type: "my.type", Extensions: map[string]string{"foo": "bar"}},
+	}, {
+		name:    "invalid json",
+		env:     `{`,
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseOverrides(test.env)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseOverrides() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ParseOverrides() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHandlerServeHTTP(t *testing.T) {
+	var gotSource, gotType, gotExt string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSource = r.Header.Get("Ce-Source")
+		gotType = r.Header.Get("Ce-Type")
+		gotExt = r.Header.Get("Ce-Foo")
+	})
+
+	h := NewHandler(next, Overrides{
+		Source:     "overridden-source",
+		Type:       "overridden.type",
+		Extensions: map[string]string{"foo": "bar"},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Ce-Id", "1234")
+	req.Header.Set("Ce-Source", "original-source")
+	req.Header.Set("Ce-Type", "original.type")
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotSource != "overridden-source" {
+		t.Errorf("Ce-Source = %q, want %q", gotSource, "overridden-source")
+	}
+	if gotType != "overridden.type" {
+		t.Errorf("Ce-Type = %q, want %q", gotType, "overridden.type")
+	}
+	if gotExt != "bar" {
+		t.Errorf("Ce-Foo = %q, want %q", gotExt, "bar")
+	}
+}
+
+func TestHandlerServeHTTPIgnoresNonCloudEvents(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if got := r.Header.Get("Ce-Source"); got != "" {
+			t.Errorf("Ce-Source should not be set, got %q", got)
+		}
+	})
+
+	h := NewHandler(next, Overrides{Source: "overridden-source"})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !called {
+		t.Fatal("expected next handler to be called")
+	}
+}
+
+func TestHandlerServeHTTPSkipsEmptyExtensionKey(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	// A zero-value extension key would previously panic on k[:1] in ServeHTTP;
+	// it should be skipped instead.
+	h := NewHandler(next, Overrides{Extensions: map[string]string{"": "bar"}})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Ce-Id", "1234")
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+}
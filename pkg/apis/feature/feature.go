@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package feature centralizes the experimental feature flags that gate
+// optional eventing behavior.
+package feature
+
+import "context"
+
+// Flag is the state of a given feature.
+type Flag string
+
+const (
+	// Enabled means the feature is turned on.
+	Enabled Flag = "Enabled"
+	// Disabled means the feature is turned off.
+	Disabled Flag = "Disabled"
+)
+
+// IsEnabled returns true if the flag is explicitly Enabled.
+func (f Flag) IsEnabled() bool {
+	return f == Enabled
+}
+
+const (
+	// OIDCAuthentication gates provisioning an OIDC service account and
+	// identity for eventing sources and their SinkBindings.
+	OIDCAuthentication = "oidc-authentication"
+)
+
+// Flags is a map of feature name to its current Flag state.
+type Flags map[string]Flag
+
+// IsOIDCAuthentication returns whether the OIDC authentication feature is enabled.
+func (f Flags) IsOIDCAuthentication() bool {
+	return f[OIDCAuthentication].IsEnabled()
+}
+
+type featureFlagsKey struct{}
+
+// ToContext stores the given Flags in the returned context.
+func ToContext(ctx context.Context, flags Flags) context.Context {
+	return context.WithValue(ctx, featureFlagsKey{}, flags)
+}
+
+// FromContext fetches the Flags stored in the given context, defaulting to an
+// empty (all-disabled) set if none were stored.
+func FromContext(ctx context.Context) Flags {
+	if f, ok := ctx.Value(featureFlagsKey{}).(Flags); ok {
+		return f
+	}
+	return Flags{}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"knative.dev/pkg/apis"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind for ContainerSources, implementing
+// kmeta.OwnerRefable so ContainerSource can be used as an owner reference source.
+func (c *ContainerSource) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ContainerSource")
+}
+
+const (
+	// ContainerSourceConditionSinkBindingReady has status True when the
+	// ContainerSource's SinkBinding is ready.
+	ContainerSourceConditionSinkBindingReady apis.ConditionType = "SinkBindingReady"
+
+	// ContainerSourceConditionDeploymentReady has status True when the
+	// ContainerSource's receive adapter is ready, whether that adapter is the
+	// default appsv1.Deployment or, when Spec.Adapter.Kind is "KnativeService",
+	// a serving.knative.dev/v1 Service.
+	ContainerSourceConditionDeploymentReady apis.ConditionType = "DeploymentReady"
+
+	// ContainerSourceConditionOIDCIdentityCreated has status True when the
+	// ContainerSource's OIDC service account has been created.
+	ContainerSourceConditionOIDCIdentityCreated apis.ConditionType = "OIDCIdentityCreated"
+
+	// ContainerSourceConditionCloudEventOverridesApplied has status True when
+	// Spec.CEOverrides has been translated into a K_CE_OVERRIDES env var value
+	// for the receive adapter, or when no overrides were requested. It does not
+	// reflect whether the receive adapter has been created or updated with it.
+	ContainerSourceConditionCloudEventOverridesApplied apis.ConditionType = "CloudEventOverridesApplied"
+
+	// ContainerSourceConditionHPAReady has status True when the HorizontalPodAutoscaler
+	// requested by Spec.Autoscaling is actively scaling the receive adapter Deployment,
+	// when no autoscaling was requested, or when the receive adapter is a Knative
+	// Service (which Spec.Autoscaling does not apply to).
+	ContainerSourceConditionHPAReady apis.ConditionType = "HPAReady"
+
+	// ContainerSourceConditionSourceTypeReady has status True when Spec.SourceType
+	// names a registered flavor whose defaulter and validator have been applied
+	// to the ContainerSource successfully, or when no SourceType was requested.
+	ContainerSourceConditionSourceTypeReady apis.ConditionType = "SourceTypeReady"
+)
+
+// containerSourceCondSet is the set of conditions that every ContainerSource
+// must satisfy to be considered Ready.
+var containerSourceCondSet = apis.NewLivingConditionSet(
+	ContainerSourceConditionSinkBindingReady,
+	ContainerSourceConditionDeploymentReady,
+	ContainerSourceConditionOIDCIdentityCreated,
+	ContainerSourceConditionCloudEventOverridesApplied,
+	ContainerSourceConditionHPAReady,
+	ContainerSourceConditionSourceTypeReady,
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*ContainerSource) GetConditionSet() apis.ConditionSet {
+	return containerSourceCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (s *ContainerSourceStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return containerSourceCondSet.Manage(s).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall.
+func (s *ContainerSourceStatus) IsReady() bool {
+	return containerSourceCondSet.Manage(s).IsHappy()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (s *ContainerSourceStatus) InitializeConditions() {
+	containerSourceCondSet.Manage(s).InitializeConditions()
+}
+
+// PropagateSinkbindingStatus propagates the SinkBinding's Ready condition onto ContainerSourceConditionSinkBindingReady.
+func (s *ContainerSourceStatus) PropagateSinkbindingStatus(sbs *SinkBindingStatus) {
+	sc := sbs.GetCondition(apis.ConditionReady)
+	if sc == nil {
+		containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionSinkBindingReady, "", "")
+		return
+	}
+	switch {
+	case sc.Status == "True":
+		containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionSinkBindingReady)
+	case sc.Status == "False":
+		containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionSinkBindingReady, sc.Reason, sc.Message)
+	default:
+		containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionSinkBindingReady, sc.Reason, sc.Message)
+	}
+}
+
+// PropagateReceiveAdapterStatus propagates the receive adapter Deployment's Available condition
+// onto ContainerSourceConditionDeploymentReady.
+func (s *ContainerSourceStatus) PropagateReceiveAdapterStatus(d *appsv1.Deployment) {
+	for _, cond := range d.Status.Conditions {
+		if cond.Type != appsv1.DeploymentAvailable {
+			continue
+		}
+		switch cond.Status {
+		case "True":
+			containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionDeploymentReady)
+		case "False":
+			containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionDeploymentReady, cond.Reason, cond.Message)
+		default:
+			containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionDeploymentReady, cond.Reason, cond.Message)
+		}
+		return
+	}
+	containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionDeploymentReady, "DeploymentUnavailable", "Deployment has no Available condition")
+}
+
+// PropagateServiceStatus propagates a Knative Service receive adapter's Ready and
+// RoutesReady conditions onto ContainerSourceConditionDeploymentReady. It is the
+// Knative Service analog of PropagateReceiveAdapterStatus, used when
+// Spec.Adapter.Kind is ContainerSourceAdapterKindKnativeService.
+func (s *ContainerSourceStatus) PropagateServiceStatus(ksvc *servingv1.Service) {
+	ready := ksvc.Status.GetCondition(servingv1.ServiceConditionReady)
+	routesReady := ksvc.Status.GetCondition(servingv1.ServiceConditionRoutesReady)
+
+	if ready == nil {
+		containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionDeploymentReady, "ServiceNotReady", "Service has no Ready condition")
+		return
+	}
+	if ready.Status != corev1.ConditionTrue {
+		containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionDeploymentReady, ready.Reason, ready.Message)
+		return
+	}
+	if routesReady != nil && routesReady.Status != corev1.ConditionTrue {
+		containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionDeploymentReady, routesReady.Reason, routesReady.Message)
+		return
+	}
+	containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionDeploymentReady)
+}
+
+// MarkOIDCIdentityCreatedSucceeded marks the OIDCIdentityCreated condition True.
+func (s *ContainerSourceStatus) MarkOIDCIdentityCreatedSucceeded() {
+	containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionOIDCIdentityCreated)
+}
+
+// MarkOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled marks the OIDCIdentityCreated condition
+// True, annotating that it was skipped because the OIDC feature flag is disabled.
+func (s *ContainerSourceStatus) MarkOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled() {
+	containerSourceCondSet.Manage(s).MarkTrueWithReason(ContainerSourceConditionOIDCIdentityCreated, "OIDCIdentityCreated", "OIDC identity not created as the feature is disabled")
+}
+
+// MarkOIDCIdentityCreatedFailed marks the OIDCIdentityCreated condition False.
+func (s *ContainerSourceStatus) MarkOIDCIdentityCreatedFailed(reason, messageFormat string, messageA ...interface{}) {
+	containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionOIDCIdentityCreated, reason, messageFormat, messageA...)
+}
+
+// MarkCloudEventOverridesApplied marks the CloudEventOverridesApplied condition True.
+func (s *ContainerSourceStatus) MarkCloudEventOverridesApplied() {
+	containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionCloudEventOverridesApplied)
+}
+
+// MarkCloudEventOverridesAppliedFailed marks the CloudEventOverridesApplied condition False.
+func (s *ContainerSourceStatus) MarkCloudEventOverridesAppliedFailed(reason, messageFormat string, messageA ...interface{}) {
+	containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionCloudEventOverridesApplied, reason, messageFormat, messageA...)
+}
+
+// MarkHPANotRequested marks the HPAReady condition True with reason
+// NoAutoscalingRequested. It is used when Spec.Autoscaling is unset, and also for a
+// Knative Service receive adapter whether or not Spec.Autoscaling is set.
+func (s *ContainerSourceStatus) MarkHPANotRequested() {
+	containerSourceCondSet.Manage(s).MarkTrueWithReason(ContainerSourceConditionHPAReady, "NoAutoscalingRequested", "No autoscaling requested")
+}
+
+// PropagateHPAStatus propagates a HorizontalPodAutoscaler's ScalingActive condition
+// onto ContainerSourceConditionHPAReady.
+func (s *ContainerSourceStatus) PropagateHPAStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	for _, cond := range hpa.Status.Conditions {
+		if cond.Type != autoscalingv2.HorizontalPodAutoscalerScalingActive {
+			continue
+		}
+		switch cond.Status {
+		case corev1.ConditionTrue:
+			containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionHPAReady)
+		case corev1.ConditionFalse:
+			containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionHPAReady, cond.Reason, cond.Message)
+		default:
+			containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionHPAReady, cond.Reason, cond.Message)
+		}
+		return
+	}
+	containerSourceCondSet.Manage(s).MarkUnknown(ContainerSourceConditionHPAReady, "HorizontalPodAutoscalerUnavailable", "HorizontalPodAutoscaler has no ScalingActive condition")
+}
+
+// MarkSourceTypeNotRequested marks the SourceTypeReady condition True, annotating that
+// no Spec.SourceType was requested.
+func (s *ContainerSourceStatus) MarkSourceTypeNotRequested() {
+	containerSourceCondSet.Manage(s).MarkTrueWithReason(ContainerSourceConditionSourceTypeReady, "NoSourceTypeRequested", "No source type requested")
+}
+
+// MarkSourceTypeReady marks the SourceTypeReady condition True.
+func (s *ContainerSourceStatus) MarkSourceTypeReady() {
+	containerSourceCondSet.Manage(s).MarkTrue(ContainerSourceConditionSourceTypeReady)
+}
+
+// MarkSourceTypeUnknown marks the SourceTypeReady condition False because Spec.SourceType
+// names a flavor that has not been registered with pkg/reconciler/containersource/types.
+func (s *ContainerSourceStatus) MarkSourceTypeUnknown(message string) {
+	containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionSourceTypeReady, "SourceTypeUnknown", message)
+}
+
+// MarkSourceTypeInvalid marks the SourceTypeReady condition False because the
+// registered flavor's defaulter or validator rejected Spec.SourceConfig.
+func (s *ContainerSourceStatus) MarkSourceTypeInvalid(message string) {
+	containerSourceCondSet.Manage(s).MarkFalse(ContainerSourceConditionSourceTypeReady, "SourceTypeInvalid", message)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auth provides helpers for provisioning the OIDC identities that
+// eventing resources use to authenticate to their sinks.
+package auth
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/pkg/kmeta"
+)
+
+// GetOIDCServiceAccountNameForResource returns the name of the OIDC service account
+// that should back the given resource.
+func GetOIDCServiceAccountNameForResource(gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) string {
+	return fmt.Sprintf("%s-%s-oidc", kmeta.ChildName(objectMeta.Name, gvk.Kind), objectMeta.UID)
+}
+
+// GetOIDCServiceAccountForResource returns the ServiceAccount that should back the
+// given resource's OIDC identity, owned by that resource.
+func GetOIDCServiceAccountForResource(gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) *corev1.ServiceAccount {
+	trueVal := true
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetOIDCServiceAccountNameForResource(gvk, objectMeta),
+			Namespace: objectMeta.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         gvk.GroupVersion().String(),
+				Kind:               gvk.Kind,
+				Name:               objectMeta.Name,
+				UID:                objectMeta.UID,
+				Controller:         &trueVal,
+				BlockOwnerDeletion: &trueVal,
+			}},
+		},
+	}
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package containersource reconciles ContainerSources into a SinkBinding and
+// a receive adapter running the user's container image.
+package containersource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingclientset "knative.dev/serving/pkg/client/clientset/versioned"
+	servingv1listers "knative.dev/serving/pkg/client/listers/serving/v1"
+
+	"knative.dev/pkg/controller"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"knative.dev/eventing/pkg/auth"
+	clientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	"knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/containersource"
+	sourcesv1listers "knative.dev/eventing/pkg/client/listers/sources/v1"
+	"knative.dev/eventing/pkg/reconciler/containersource/resources"
+	ctypes "knative.dev/eventing/pkg/reconciler/containersource/types"
+)
+
+const (
+	sinkBindingCreated = "SinkBindingCreated"
+	sinkBindingUpdated = "SinkBindingUpdated"
+	deploymentCreated  = "DeploymentCreated"
+	deploymentUpdated  = "DeploymentUpdated"
+	deploymentDeleted  = "DeploymentDeleted"
+	serviceCreated     = "ServiceCreated"
+	serviceUpdated     = "ServiceUpdated"
+	serviceDeleted     = "ServiceDeleted"
+	hpaCreated         = "HorizontalPodAutoscalerCreated"
+	hpaUpdated         = "HorizontalPodAutoscalerUpdated"
+	hpaDeleted         = "HorizontalPodAutoscalerDeleted"
+	sourceReconciled   = "ContainerSourceReconciled"
+)
+
+// Reconciler reconciles a ContainerSource object.
+type Reconciler struct {
+	kubeClientSet     kubernetes.Interface
+	eventingClientSet clientset.Interface
+	servingClientSet  servingclientset.Interface
+
+	containerSourceLister sourcesv1listers.ContainerSourceLister
+	deploymentLister      appsv1listers.DeploymentLister
+	sinkBindingLister     sourcesv1listers.SinkBindingLister
+	serviceAccountLister  corev1listers.ServiceAccountLister
+	serviceLister         servingv1listers.ServiceLister
+	hpaLister             autoscalingv2listers.HorizontalPodAutoscalerLister
+}
+
+// Check that our Reconciler implements containersource.Interface.
+var _ containersource.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements containersource.Interface.
+func (r *Reconciler) ReconcileKind(ctx context.Context, source *sourcesv1.ContainerSource) pkgreconciler.Event {
+	sb, err := r.reconcileSinkBinding(ctx, source)
+	if err != nil {
+		return fmt.Errorf("creating new SinkBinding: %w", err)
+	}
+	source.Status.PropagateSinkbindingStatus(&sb.Status)
+
+	if err := r.reconcileOIDCServiceAccount(ctx, source); err != nil {
+		return err
+	}
+
+	if err := reconcileSourceType(source); err != nil {
+		return fmt.Errorf("applying source type: %w", err)
+	}
+
+	if err := reconcileCloudEventOverrides(source); err != nil {
+		return fmt.Errorf("applying CloudEvent overrides: %w", err)
+	}
+
+	if source.Spec.IsKnativeService() {
+		if err := r.deleteStaleDeployment(ctx, source); err != nil {
+			return fmt.Errorf("cleaning up stale Deployment: %w", err)
+		}
+
+		ksvc, err := r.reconcileService(ctx, source)
+		if err != nil {
+			return fmt.Errorf("creating new Service: %w", err)
+		}
+		source.Status.PropagateServiceStatus(ksvc)
+		// A Knative Service adapter scales itself; Spec.Autoscaling does not apply.
+		source.Status.MarkHPANotRequested()
+	} else {
+		if err := r.deleteStaleService(ctx, source); err != nil {
+			return fmt.Errorf("cleaning up stale Service: %w", err)
+		}
+
+		d, err := r.reconcileDeployment(ctx, source)
+		if err != nil {
+			return fmt.Errorf("creating new Deployment: %w", err)
+		}
+		source.Status.PropagateReceiveAdapterStatus(d)
+
+		if err := r.reconcileHPA(ctx, source); err != nil {
+			return fmt.Errorf("reconciling HorizontalPodAutoscaler: %w", err)
+		}
+	}
+
+	return pkgreconciler.NewEvent(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, source.Namespace, source.Name)
+}
+
+func (r *Reconciler) reconcileSinkBinding(ctx context.Context, source *sourcesv1.ContainerSource) (*sourcesv1.SinkBinding, error) {
+	name := resources.SinkBindingName(source)
+
+	sb, err := r.sinkBindingLister.SinkBindings(source.Namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		want := resources.MakeSinkBinding(source)
+		sb, err = r.eventingClientSet.SourcesV1().SinkBindings(source.Namespace).Create(ctx, want, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sb.Name)
+		return sb, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	want := resources.MakeSinkBinding(source)
+	if equality.Semantic.DeepEqual(want.Spec, sb.Spec) {
+		return sb, nil
+	}
+
+	want.ResourceVersion = sb.ResourceVersion
+	sb, err = r.eventingClientSet.SourcesV1().SinkBindings(source.Namespace).Update(ctx, want, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, sinkBindingUpdated, "SinkBinding updated %q", sb.Name)
+	return sb, nil
+}
+
+func (r *Reconciler) reconcileDeployment(ctx context.Context, source *sourcesv1.ContainerSource) (*appsv1.Deployment, error) {
+	name := resources.DeploymentName(source)
+
+	d, err := r.deploymentLister.Deployments(source.Namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		want, err := resources.MakeDeployment(source)
+		if err != nil {
+			return nil, err
+		}
+		d, err = r.kubeClientSet.AppsV1().Deployments(source.Namespace).Create(ctx, want, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, deploymentCreated, "Deployment created %q", d.Name)
+		return d, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	want, err := resources.MakeDeployment(source)
+	if err != nil {
+		return nil, err
+	}
+	if source.Spec.Autoscaling != nil {
+		// The HPA, not this reconciler, owns Replicas once autoscaling is enabled: leave
+		// whatever the scale subresource has set so we don't fight it back down to the floor.
+		want.Spec.Replicas = d.Spec.Replicas
+	}
+	if equality.Semantic.DeepEqual(want.Spec, d.Spec) {
+		return d, nil
+	}
+
+	want.ResourceVersion = d.ResourceVersion
+	d, err = r.kubeClientSet.AppsV1().Deployments(source.Namespace).Update(ctx, want, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, deploymentUpdated, "Deployment updated %q", d.Name)
+	return d, nil
+}
+
+func (r *Reconciler) reconcileService(ctx context.Context, source *sourcesv1.ContainerSource) (*servingv1.Service, error) {
+	name := resources.ServiceName(source)
+
+	ksvc, err := r.serviceLister.Services(source.Namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		want, err := resources.MakeService(source)
+		if err != nil {
+			return nil, err
+		}
+		ksvc, err = r.servingClientSet.ServingV1().Services(source.Namespace).Create(ctx, want, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, serviceCreated, "Service created %q", ksvc.Name)
+		return ksvc, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	want, err := resources.MakeService(source)
+	if err != nil {
+		return nil, err
+	}
+	if equality.Semantic.DeepEqual(want.Spec, ksvc.Spec) {
+		return ksvc, nil
+	}
+
+	want.ResourceVersion = ksvc.ResourceVersion
+	ksvc, err = r.servingClientSet.ServingV1().Services(source.Namespace).Update(ctx, want, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, serviceUpdated, "Service updated %q", ksvc.Name)
+	return ksvc, nil
+}
+
+// deleteStaleDeployment deletes the receive adapter Deployment, and any HPA scaling it,
+// left behind when Spec.Adapter.Kind switches away from the default Deployment adapter
+// to KnativeService. reconcileService doesn't manage either, so without this they would
+// keep running, owned but otherwise unreconciled, forever. It is a no-op if neither exists.
+func (r *Reconciler) deleteStaleDeployment(ctx context.Context, source *sourcesv1.ContainerSource) error {
+	name := resources.DeploymentName(source)
+	if _, err := r.deploymentLister.Deployments(source.Namespace).Get(name); err == nil {
+		if err := r.kubeClientSet.AppsV1().Deployments(source.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, deploymentDeleted, "Deployment deleted %q", name)
+	} else if !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	hpaName := resources.HPAName(source)
+	if _, err := r.hpaLister.HorizontalPodAutoscalers(source.Namespace).Get(hpaName); apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := r.kubeClientSet.AutoscalingV2().HorizontalPodAutoscalers(source.Namespace).Delete(ctx, hpaName, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, hpaDeleted, "HorizontalPodAutoscaler deleted %q", hpaName)
+	return nil
+}
+
+// deleteStaleService deletes the receive adapter Knative Service left behind when
+// Spec.Adapter.Kind switches away from KnativeService back to the default Deployment
+// adapter. reconcileDeployment doesn't manage it, so without this it would keep running,
+// owned but otherwise unreconciled, forever. It is a no-op if the Service doesn't exist.
+func (r *Reconciler) deleteStaleService(ctx context.Context, source *sourcesv1.ContainerSource) error {
+	name := resources.ServiceName(source)
+	if _, err := r.serviceLister.Services(source.Namespace).Get(name); apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := r.servingClientSet.ServingV1().Services(source.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, serviceDeleted, "Service deleted %q", name)
+	return nil
+}
+
+// reconcileHPA creates, updates, or deletes the HorizontalPodAutoscaler that scales the
+// receive adapter Deployment according to Spec.Autoscaling, and reflects the outcome
+// onto the HPAReady condition.
+func (r *Reconciler) reconcileHPA(ctx context.Context, source *sourcesv1.ContainerSource) error {
+	name := resources.HPAName(source)
+
+	existing, err := r.hpaLister.HorizontalPodAutoscalers(source.Namespace).Get(name)
+	switch {
+	case apierrs.IsNotFound(err):
+		if source.Spec.Autoscaling == nil {
+			source.Status.MarkHPANotRequested()
+			return nil
+		}
+		want, err := resources.MakeHPA(source)
+		if err != nil {
+			return err
+		}
+		created, err := r.kubeClientSet.AutoscalingV2().HorizontalPodAutoscalers(source.Namespace).Create(ctx, want, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, hpaCreated, "HorizontalPodAutoscaler created %q", created.Name)
+		source.Status.PropagateHPAStatus(created)
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if source.Spec.Autoscaling == nil {
+		if err := r.kubeClientSet.AutoscalingV2().HorizontalPodAutoscalers(source.Namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, hpaDeleted, "HorizontalPodAutoscaler deleted %q", name)
+		source.Status.MarkHPANotRequested()
+		return nil
+	}
+
+	want, err := resources.MakeHPA(source)
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(want.Spec, existing.Spec) {
+		source.Status.PropagateHPAStatus(existing)
+		return nil
+	}
+
+	want.ResourceVersion = existing.ResourceVersion
+	updated, err := r.kubeClientSet.AutoscalingV2().HorizontalPodAutoscalers(source.Namespace).Update(ctx, want, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, hpaUpdated, "HorizontalPodAutoscaler updated %q", updated.Name)
+	source.Status.PropagateHPAStatus(updated)
+	return nil
+}
+
+// reconcileSourceType applies the Flavor registered under Spec.SourceType (if any) to source,
+// defaulting and validating Spec.SourceConfig and the receive adapter Template, and reflects
+// the outcome onto the SourceTypeReady condition.
+func reconcileSourceType(source *sourcesv1.ContainerSource) error {
+	err := ctypes.Apply(source)
+	switch {
+	case err == nil:
+		if source.Spec.SourceType == "" {
+			source.Status.MarkSourceTypeNotRequested()
+		} else {
+			source.Status.MarkSourceTypeReady()
+		}
+		return nil
+	case errors.As(err, new(ctypes.ErrUnknownSourceType)):
+		source.Status.MarkSourceTypeUnknown(err.Error())
+		return err
+	default:
+		source.Status.MarkSourceTypeInvalid(err.Error())
+		return err
+	}
+}
+
+// reconcileCloudEventOverrides validates that Spec.CEOverrides can be translated into
+// the K_CE_OVERRIDES env var the receive adapter's own container reads, and reflects the
+// outcome onto the CloudEventOverridesApplied condition.
+func reconcileCloudEventOverrides(source *sourcesv1.ContainerSource) error {
+	if _, _, err := resources.MakeCloudEventOverridesEnv(source); err != nil {
+		source.Status.MarkCloudEventOverridesAppliedFailed("CloudEventOverridesInvalid", err.Error())
+		return err
+	}
+	source.Status.MarkCloudEventOverridesApplied()
+	return nil
+}
+
+// reconcileOIDCServiceAccount ensures a ServiceAccount exists for the ContainerSource to use
+// as its OIDC identity when the feature is enabled, and reflects the outcome onto the status.
+func (r *Reconciler) reconcileOIDCServiceAccount(ctx context.Context, source *sourcesv1.ContainerSource) error {
+	if !feature.FromContext(ctx).IsOIDCAuthentication() {
+		source.Status.MarkOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled()
+		return nil
+	}
+
+	gvk := sourcesv1.SchemeGroupVersion.WithKind("ContainerSource")
+	want := auth.GetOIDCServiceAccountForResource(gvk, source.ObjectMeta)
+
+	sa, err := r.serviceAccountLister.ServiceAccounts(source.Namespace).Get(want.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		sa, err = r.kubeClientSet.CoreV1().ServiceAccounts(source.Namespace).Create(ctx, want, metav1.CreateOptions{})
+		if err != nil {
+			source.Status.MarkOIDCIdentityCreatedFailed("Unable to create OIDC service account", err.Error())
+			return err
+		}
+	case err != nil:
+		source.Status.MarkOIDCIdentityCreatedFailed("Unable to get the OIDC service account", err.Error())
+		return err
+	}
+
+	source.Status.OIDCServiceAccountName = sa.Name
+
+	if !isOwnedBy(sa.OwnerReferences, source.UID) {
+		err := fmt.Errorf("service account %s not owned by ContainerSource %s", sa.Name, source.Name)
+		source.Status.MarkOIDCIdentityCreatedFailed("Unable to resolve service account for OIDC authentication", err.Error())
+		return err
+	}
+
+	source.Status.MarkOIDCIdentityCreatedSucceeded()
+	return nil
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, or := range refs {
+		if or.UID == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ package containersource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -25,6 +26,7 @@ import (
 	"knative.dev/pkg/tracker"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,11 +36,14 @@ import (
 	"knative.dev/pkg/apis"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	"knative.dev/pkg/logging"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	fakeservingclient "knative.dev/serving/pkg/client/injection/client/fake"
 
 	"knative.dev/eventing/pkg/apis/feature"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/containersource"
 	"knative.dev/eventing/pkg/reconciler/containersource/resources"
+	ctypes "knative.dev/eventing/pkg/reconciler/containersource/types"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
@@ -46,6 +51,7 @@ import (
 	"knative.dev/pkg/controller"
 
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
 	. "knative.dev/pkg/reconciler/testing"
 
 	. "knative.dev/eventing/pkg/reconciler/testing/v1"
@@ -58,13 +64,39 @@ const (
 	testNS     = "testnamespace"
 	sinkName   = "testsink"
 	generation = 1
+
+	unknownFlavorName      = "does-not-exist"
+	testFlavorName         = "test-flavor"
+	testFlavorImage        = "github.com/knative/test/flavor-image"
+	testFlavorEnvName      = "FLAVOR_EXTRA"
+	testFlavorEnvValue     = "flavor-value"
+	rejectingFlavorName    = "rejecting-flavor"
+	rejectingFlavorMessage = "sourceConfig.foo is required"
 )
 
+func init() {
+	ctypes.Register(ctypes.Flavor{
+		Name:         testFlavorName,
+		DefaultImage: testFlavorImage,
+		ExtraEnv: func(*runtime.RawExtension) ([]corev1.EnvVar, error) {
+			return []corev1.EnvVar{{Name: testFlavorEnvName, Value: testFlavorEnvValue}}, nil
+		},
+	})
+	ctypes.Register(ctypes.Flavor{
+		Name: rejectingFlavorName,
+		Validator: func(*runtime.RawExtension) error {
+			return errors.New(rejectingFlavorMessage)
+		},
+	})
+}
+
 var (
 	trueVal = true
 
 	deploymentName  = fmt.Sprintf("%s-deployment", sourceName)
 	sinkBindingName = fmt.Sprintf("%s-sinkbinding", sourceName)
+	hpaName         = fmt.Sprintf("%s-hpa", sourceName)
+	serviceName     = fmt.Sprintf("%s-service", sourceName)
 
 	conditionTrue = corev1.ConditionTrue
 
@@ -92,153 +124,811 @@ func TestAllCases(t *testing.T) {
 			Objects: []runtime.Object{
 				NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
-					WithContainerSourceObjectMetaGeneration(generation),
-				),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("create", "sinkbindings"),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", "creating new SinkBinding: inducing failure for %s %s", "create", "sinkbindings"),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerUnobservedGeneration(),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "error creating deployment",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("create", "deployments"),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeWarning, "InternalError", "creating new Deployment: inducing failure for %s %s", "create", "deployments"),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceStatusObservedGeneration(generation),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "successfully reconciled and not ready",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeNormal, deploymentCreated, "Deployment created %q", deploymentName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), nil)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "successfully reconciled with CE overrides",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceCloudEventOverridesSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeNormal, deploymentCreated, "Deployment created %q", deploymentName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceCloudEventOverridesSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceCloudEventOverridesSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), nil)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceCloudEventOverridesSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				// makeDeployment folds Spec.CEOverrides into K_CE_OVERRIDES via
+				// resources.MakeCloudEventOverridesEnv, so this asserts the env var lands
+				// on the created Deployment.
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceCloudEventOverridesSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "successfully reconciled and ready",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+		}, {
+			Name: "error creating knative service",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("create", "services"),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeWarning, "InternalError", "creating new Service: inducing failure for %s %s", "create", "services"),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceStatusObservedGeneration(generation),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBindingForService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				makeService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "successfully reconciled knative service and not ready",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeNormal, serviceCreated, "Service created %q", serviceName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateServiceStatus(makeService(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), nil)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBindingForService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				makeService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "error updating knative service",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBindingForService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				func() *servingv1.Service {
+					ksvc := makeService(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)
+					ksvc.Spec.Template.Spec.Containers[0].Image = "gcr.io/stale-image"
+					return ksvc
+				}(),
+			},
+			Key: testNS + "/" + sourceName,
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("update", "services"),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", "creating new Service: inducing failure for %s %s", "update", "services"),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+				),
+			}},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: makeService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			}},
+		}, {
+			Name: "successfully reconciled knative service and ready",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBindingForService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateServiceStatus(makeService(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+		}, {
+			Name: "cleans up stale Deployment and HPA when adapter switches to knative service",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBindingForService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				// Left behind from when Spec.Adapter.Kind was still Deployment.
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, deploymentDeleted, "Deployment deleted %q", deploymentName),
+				Eventf(corev1.EventTypeNormal, hpaDeleted, "HorizontalPodAutoscaler deleted %q", hpaName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateServiceStatus(makeService(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+			WantDeletes: []clientgotesting.DeleteActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+				},
+				Name: deploymentName,
+			}, {
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+				},
+				Name: hpaName,
+			}},
+		}, {
+			Name: "cleans up stale Service when adapter switches to deployment",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				// Left behind from when Spec.Adapter.Kind was still KnativeService.
+				makeService(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceServiceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, serviceDeleted, "Service deleted %q", serviceName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+			WantDeletes: []clientgotesting.DeleteActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+				},
+				Name: serviceName,
+			}},
+		}, {
+			Name: "OIDC: creates OIDC service account",
+			Key:  testNS + "/" + sourceName,
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			WantErr: false,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourceOIDCIdentityCreatedSucceeded(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceOIDCServiceAccountName(makeContainerSourceOIDCServiceAccount().Name),
+					WithContainerSourceHPANotRequested(),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantCreates: []runtime.Object{
+				makeContainerSourceOIDCServiceAccount(),
+			},
+		}, {
+			Name: "OIDC: Containersource not ready on invalid OIDC service account",
+			Key:  testNS + "/" + sourceName,
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				makeContainerSourceOIDCServiceAccountWithoutOwnerRef(),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedFailed("Unable to resolve service account for OIDC authentication", fmt.Sprintf("service account %s not owned by ContainerSource %s", makeContainerSourceOIDCServiceAccountWithoutOwnerRef().Name, sourceName)),
+					WithContainerSourceOIDCServiceAccountName(makeContainerSourceOIDCServiceAccountWithoutOwnerRef().Name),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("service account %s not owned by ContainerSource %s", makeContainerSourceOIDCServiceAccountWithoutOwnerRef().Name, sourceName)),
+			},
+		}, {
+			Name: "error creating HPA",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WithReactors: []clientgotesting.ReactionFunc{
+				InduceFailure("create", "horizontalpodautoscalers"),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", "reconciling HorizontalPodAutoscaler: inducing failure for %s %s", "create", "horizontalpodautoscalers"),
+			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "successfully created HPA and not ready",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
 			},
 			Key: testNS + "/" + sourceName,
-			WithReactors: []clientgotesting.ReactionFunc{
-				InduceFailure("create", "sinkbindings"),
-			},
 			WantEvents: []string{
-				Eventf(corev1.EventTypeWarning, "InternalError", "creating new SinkBinding: inducing failure for %s %s", "create", "sinkbindings"),
+				Eventf(corev1.EventTypeNormal, hpaCreated, "HorizontalPodAutoscaler created %q", hpaName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
 			},
-			WantErr: true,
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
 					WithContainerSourceObjectMetaGeneration(generation),
 					WithInitContainerSourceConditions,
 					WithContainerSourceStatusObservedGeneration(generation),
-					WithContainerUnobservedGeneration(),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourcePropagateHPAStatus(makeHPA(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), nil)),
 				),
 			}},
 			WantCreates: []runtime.Object{
-				makeSinkBinding(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceUID(sourceUID),
 				), nil),
 			},
 		}, {
-			Name: "error creating deployment",
+			Name: "successfully reconciled HPA and ready",
 			Objects: []runtime.Object{
 				NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceObjectMetaGeneration(generation),
 				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
 			},
 			Key: testNS + "/" + sourceName,
-			WithReactors: []clientgotesting.ReactionFunc{
-				InduceFailure("create", "deployments"),
-			},
 			WantEvents: []string{
-				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
-				Eventf(corev1.EventTypeWarning, "InternalError", "creating new Deployment: inducing failure for %s %s", "create", "deployments"),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
 			},
-			WantErr: true,
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
 					WithContainerSourceObjectMetaGeneration(generation),
 					WithInitContainerSourceConditions,
-					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+					WithContainerSourcePropagateHPAStatus(makeHPA(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
 				),
 			}},
-			WantCreates: []runtime.Object{
-				makeSinkBinding(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
-					WithContainerSourceUID(sourceUID),
-				), nil),
-				makeDeployment(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
-					WithContainerSourceUID(sourceUID),
-				), nil),
-			},
 		}, {
-			Name: "successfully reconciled and not ready",
+			Name: "does not fight HPA-scaled replicas",
 			Objects: []runtime.Object{
 				NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceObjectMetaGeneration(generation),
 				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				func() *appsv1.Deployment {
+					d := makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)
+					d.Spec.Replicas = ptr.Int32(5) // HPA has scaled the Deployment up via the scale subresource.
+					return d
+				}(),
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
 			},
 			Key: testNS + "/" + sourceName,
 			WantEvents: []string{
-				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
-				Eventf(corev1.EventTypeNormal, deploymentCreated, "Deployment created %q", deploymentName),
 				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
 					WithContainerSourceObjectMetaGeneration(generation),
 					WithInitContainerSourceConditions,
-					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
 					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
-						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 						WithContainerSourceUID(sourceUID),
-					), nil)),
+					), &conditionTrue)),
+					WithContainerSourcePropagateHPAStatus(makeHPA(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
 				),
 			}},
-			WantCreates: []runtime.Object{
-				makeSinkBinding(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
-					WithContainerSourceUID(sourceUID),
-				), nil),
-				makeDeployment(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
-					WithContainerSourceUID(sourceUID),
-				), nil),
-			},
+			// No Deployment update: MakeDeployment's floor of 1 must not override the
+			// live replica count the HPA has already set via the scale subresource.
 		}, {
-			Name: "successfully reconciled and ready",
+			Name: "updates HPA when autoscaling spec changes",
 			Objects: []runtime.Object{
 				NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceObjectMetaGeneration(generation),
 				),
 				makeSinkBinding(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceUID(sourceUID),
 				), &conditionTrue),
 				makeDeployment(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceUID(sourceUID),
 				), &conditionTrue),
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpecWithMax(sinkDest, 3)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
 			},
 			Key: testNS + "/" + sourceName,
 			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, hpaUpdated, "HorizontalPodAutoscaler updated %q", hpaName),
 				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
 			},
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
 					WithContainerSourceObjectMetaGeneration(generation),
 					WithInitContainerSourceConditions,
 					WithContainerSourceStatusObservedGeneration(generation),
 					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
 					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
-						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
 						WithContainerSourceUID(sourceUID),
 					), &conditionTrue)),
+					WithContainerSourcePropagateHPAStatus(makeHPA(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), nil)),
 				),
 			}},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			}},
 		}, {
-			Name: "OIDC: creates OIDC service account",
-			Key:  testNS + "/" + sourceName,
-			Ctx: feature.ToContext(context.Background(), feature.Flags{
-				feature.OIDCAuthentication: feature.Enabled,
-			}),
+			Name: "deletes HPA when autoscaling removed from spec",
 			Objects: []runtime.Object{
 				NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
@@ -253,12 +943,23 @@ func TestAllCases(t *testing.T) {
 					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
 					WithContainerSourceUID(sourceUID),
 				), &conditionTrue),
+				makeHPA(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceAutoscalingSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, hpaDeleted, "HorizontalPodAutoscaler deleted %q", hpaName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
 			},
-			WantErr: false,
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
 					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeNotRequested(),
+					WithContainerSourceCloudEventOverridesApplied(),
 					WithContainerSourceObjectMetaGeneration(generation),
 					WithInitContainerSourceConditions,
 					WithContainerSourceStatusObservedGeneration(generation),
@@ -267,48 +968,120 @@ func TestAllCases(t *testing.T) {
 						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
 						WithContainerSourceUID(sourceUID),
 					), &conditionTrue)),
-					WithContainerSourceOIDCIdentityCreatedSucceeded(),
-					WithContainerSourceOIDCServiceAccountName(makeContainerSourceOIDCServiceAccount().Name),
+					WithContainerSourceHPANotRequested(),
 				),
 			}},
+			WantDeletes: []clientgotesting.DeleteActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+				},
+				Name: hpaName,
+			}},
+		}, {
+			Name: "unknown source type",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, unknownFlavorName)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
 			WantEvents: []string{
-				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeWarning, "InternalError", `applying source type: unknown source type "does-not-exist"`),
 			},
+			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, unknownFlavorName)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeUnknown(`unknown source type "does-not-exist"`),
+					WithContainerSourceStatusObservedGeneration(generation),
+				),
+			}},
 			WantCreates: []runtime.Object{
-				makeContainerSourceOIDCServiceAccount(),
-			},
-		}, {
-			Name: "OIDC: Containersource not ready on invalid OIDC service account",
-			Key:  testNS + "/" + sourceName,
-			Ctx: feature.ToContext(context.Background(), feature.Flags{
-				feature.OIDCAuthentication: feature.Enabled,
-			}),
-			Objects: []runtime.Object{
-				makeContainerSourceOIDCServiceAccountWithoutOwnerRef(),
 				makeSinkBinding(NewContainerSource(sourceName, testNS,
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, unknownFlavorName)),
 					WithContainerSourceUID(sourceUID),
 				), nil),
+			},
+		}, {
+			Name: "source type validator rejects sourceConfig",
+			Objects: []runtime.Object{
 				NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, rejectingFlavorName)),
 					WithContainerSourceObjectMetaGeneration(generation),
 				),
 			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeWarning, "InternalError", "applying source type: "+rejectingFlavorMessage),
+			},
 			WantErr: true,
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, rejectingFlavorName)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeInvalid(rejectingFlavorMessage),
 					WithContainerSourceStatusObservedGeneration(generation),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, rejectingFlavorName)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+			},
+		}, {
+			Name: "known source type applies flavor defaults",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, testFlavorName)),
 					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sinkBindingCreated, "SinkBinding created %q", sinkBindingName),
+				Eventf(corev1.EventTypeNormal, deploymentCreated, "Deployment created %q", deploymentName),
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
 					WithContainerSourceUID(sourceUID),
-					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, testFlavorName)),
+					WithContainerSourceObjectMetaGeneration(generation),
 					WithInitContainerSourceConditions,
-					WithContainerSourceOIDCIdentityCreatedFailed("Unable to resolve service account for OIDC authentication", fmt.Sprintf("service account %s not owned by ContainerSource %s", makeContainerSourceOIDCServiceAccountWithoutOwnerRef().Name, sourceName)),
-					WithContainerSourceOIDCServiceAccountName(makeContainerSourceOIDCServiceAccountWithoutOwnerRef().Name),
+					WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+					WithContainerSourceSourceTypeReady(),
+					WithContainerSourceCloudEventOverridesApplied(),
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceSourceTypeSpecDefaulted(sinkDest, testFlavorName)),
+						WithContainerSourceUID(sourceUID),
+					), nil)),
+					WithContainerSourceHPANotRequested(),
 				),
 			}},
-			WantEvents: []string{
-				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("service account %s not owned by ContainerSource %s", makeContainerSourceOIDCServiceAccountWithoutOwnerRef().Name, sourceName)),
+			WantCreates: []runtime.Object{
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpec(sinkDest, testFlavorName)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSourceTypeSpecDefaulted(sinkDest, testFlavorName)),
+					WithContainerSourceUID(sourceUID),
+				), nil),
 			},
 		},
 	}
@@ -319,10 +1092,13 @@ func TestAllCases(t *testing.T) {
 		r := &Reconciler{
 			kubeClientSet:         fakekubeclient.Get(ctx),
 			eventingClientSet:     fakeeventingclient.Get(ctx),
+			servingClientSet:      fakeservingclient.Get(ctx),
 			containerSourceLister: listers.GetContainerSourceLister(),
 			deploymentLister:      listers.GetDeploymentLister(),
 			sinkBindingLister:     listers.GetSinkBindingLister(),
 			serviceAccountLister:  listers.GetServiceAccountLister(),
+			serviceLister:         listers.GetServiceLister(),
+			hpaLister:             listers.GetHPALister(),
 		}
 		return containersource.NewReconciler(ctx, logging.FromContext(ctx), fakeeventingclient.Get(ctx), listers.GetContainerSourceLister(), controller.GetEventRecorder(ctx), r)
 	},
@@ -359,7 +1135,7 @@ func makeSinkBinding(source *sourcesv1.ContainerSource, ready *corev1.ConditionS
 }
 
 func makeDeployment(source *sourcesv1.ContainerSource, available *corev1.ConditionStatus) *appsv1.Deployment {
-	template := source.Spec.Template
+	template := *source.Spec.Template.DeepCopy()
 
 	if template.Labels == nil {
 		template.Labels = make(map[string]string)
@@ -367,6 +1143,13 @@ func makeDeployment(source *sourcesv1.ContainerSource, available *corev1.Conditi
 	for k, v := range resources.Labels(source.Name) {
 		template.Labels[k] = v
 	}
+	if env, ok, err := resources.MakeCloudEventOverridesEnv(source); err != nil {
+		panic(err)
+	} else if ok {
+		for i := range template.Spec.Containers {
+			template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env, env)
+		}
+	}
 
 	status := appsv1.DeploymentStatus{}
 	if available != nil {
@@ -393,6 +1176,7 @@ func makeDeployment(source *sourcesv1.ContainerSource, available *corev1.Conditi
 			Labels:          resources.Labels(source.Name),
 		},
 		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(wantReplicas(source)),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: resources.Labels(source.Name),
 			},
@@ -402,6 +1186,18 @@ func makeDeployment(source *sourcesv1.ContainerSource, available *corev1.Conditi
 	}
 }
 
+// wantReplicas mirrors resources.desiredReplicas: Spec.Replicas when set, else the
+// autoscaler's floor, else 1.
+func wantReplicas(source *sourcesv1.ContainerSource) int32 {
+	if source.Spec.Replicas != nil {
+		return *source.Spec.Replicas
+	}
+	if source.Spec.Autoscaling != nil && source.Spec.Autoscaling.MinReplicas != nil {
+		return *source.Spec.Autoscaling.MinReplicas
+	}
+	return 1
+}
+
 func getOwnerReferences() []metav1.OwnerReference {
 	return []metav1.OwnerReference{{
 		APIVersion:         sourcesv1.SchemeGroupVersion.String(),
@@ -432,6 +1228,122 @@ func makeContainerSourceSpec(sink duckv1.Destination) sourcesv1.ContainerSourceS
 	}
 }
 
+func makeContainerSourceServiceSpec(sink duckv1.Destination) sourcesv1.ContainerSourceSpec {
+	spec := makeContainerSourceSpec(sink)
+	spec.Adapter = &sourcesv1.ContainerSourceAdapterOverride{
+		Kind: sourcesv1.ContainerSourceAdapterKindKnativeService,
+	}
+	return spec
+}
+
+func makeContainerSourceAutoscalingSpec(sink duckv1.Destination) sourcesv1.ContainerSourceSpec {
+	return makeContainerSourceAutoscalingSpecWithMax(sink, 5)
+}
+
+func makeContainerSourceAutoscalingSpecWithMax(sink duckv1.Destination, max int32) sourcesv1.ContainerSourceSpec {
+	spec := makeContainerSourceSpec(sink)
+	spec.Autoscaling = &sourcesv1.ContainerSourceAutoscaling{
+		MaxReplicas:                    max,
+		TargetCPUUtilizationPercentage: ptr.Int32(80),
+	}
+	return spec
+}
+
+func makeContainerSourceCloudEventOverridesSpec(sink duckv1.Destination) sourcesv1.ContainerSourceSpec {
+	spec := makeContainerSourceSpec(sink)
+	spec.CEOverrides = &sourcesv1.ContainerSourceCloudEventOverrides{
+		Source: "my-source",
+		Extensions: map[string]string{
+			"foo": "bar",
+		},
+	}
+	return spec
+}
+
+func makeContainerSourceSourceTypeSpec(sink duckv1.Destination, sourceType string) sourcesv1.ContainerSourceSpec {
+	spec := makeContainerSourceSpec(sink)
+	spec.Template.Spec.Containers[0].Image = ""
+	spec.SourceType = sourceType
+	return spec
+}
+
+func makeContainerSourceSourceTypeSpecDefaulted(sink duckv1.Destination, sourceType string) sourcesv1.ContainerSourceSpec {
+	spec := makeContainerSourceSourceTypeSpec(sink, sourceType)
+	spec.Template.Spec.Containers[0].Image = testFlavorImage
+	spec.Template.Spec.Containers[0].Env = append(spec.Template.Spec.Containers[0].Env,
+		corev1.EnvVar{Name: testFlavorEnvName, Value: testFlavorEnvValue})
+	return spec
+}
+
+func makeHPA(source *sourcesv1.ContainerSource, scalingActive *corev1.ConditionStatus) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa, err := resources.MakeHPA(source)
+	if err != nil {
+		panic(err)
+	}
+	if scalingActive != nil {
+		hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{{
+			Type:   autoscalingv2.HorizontalPodAutoscalerScalingActive,
+			Status: *scalingActive,
+		}}
+	}
+	return hpa
+}
+
+func makeSinkBindingForService(source *sourcesv1.ContainerSource, ready *corev1.ConditionStatus) *sourcesv1.SinkBinding {
+	sb := makeSinkBinding(source, ready)
+	sb.Spec.Subject = tracker.Reference{
+		APIVersion: servingv1.SchemeGroupVersion.String(),
+		Kind:       "Service",
+		Namespace:  source.Namespace,
+		Name:       resources.ServiceName(source),
+	}
+	return sb
+}
+
+func makeService(source *sourcesv1.ContainerSource, ready *corev1.ConditionStatus) *servingv1.Service {
+	template := source.Spec.Template
+
+	if template.Labels == nil {
+		template.Labels = make(map[string]string)
+	}
+	for k, v := range resources.Labels(source.Name) {
+		template.Labels[k] = v
+	}
+
+	status := servingv1.ServiceStatus{}
+	if ready != nil {
+		status.Conditions = duckv1.Conditions{{
+			Type:   servingv1.ServiceConditionReady,
+			Status: *ready,
+		}, {
+			Type:   servingv1.ServiceConditionRoutesReady,
+			Status: *ready,
+		}}
+	}
+
+	return &servingv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-service", sourceName),
+			Namespace:       source.Namespace,
+			OwnerReferences: getOwnerReferences(),
+			Labels:          resources.Labels(source.Name),
+		},
+		Spec: servingv1.ServiceSpec{
+			ConfigurationSpec: servingv1.ConfigurationSpec{
+				Template: servingv1.RevisionTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: template.Labels,
+					},
+					Spec: servingv1.RevisionSpec{
+						PodSpec: template.Spec,
+					},
+				},
+			},
+		},
+		Status: status,
+	}
+}
+
 func makeSinkBindingStatus(ready *corev1.ConditionStatus) *sourcesv1.SinkBindingStatus {
 	return &sourcesv1.SinkBindingStatus{
 		SourceStatus: duckv1.SourceStatus{
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// CloudEventOverridesEnvVar is the name of the env var the receive adapter's own
+// container reads its context overrides from.
+const CloudEventOverridesEnvVar = "K_CE_OVERRIDES"
+
+// MakeCloudEventOverridesEnv translates a ContainerSource's Spec.CEOverrides into the
+// K_CE_OVERRIDES env var the receive adapter container is expected to apply itself,
+// mirroring how the SinkBinding webhook injects K_SINK. It returns ok=false when the
+// source has no overrides to apply. See pkg/adapter/containersource/proxy for a
+// reusable CloudEvents-rewriting http.Handler that applies K_CE_OVERRIDES; it isn't
+// wired in as a sidecar here (see that package's doc comment for why).
+func MakeCloudEventOverridesEnv(source *sourcesv1.ContainerSource) (env corev1.EnvVar, ok bool, err error) {
+	overrides := source.Spec.CEOverrides
+	if overrides == nil {
+		return corev1.EnvVar{}, false, nil
+	}
+
+	b, err := json.Marshal(overrides)
+	if err != nil {
+		return corev1.EnvVar{}, false, fmt.Errorf("marshalling CloudEventOverrides: %w", err)
+	}
+
+	return corev1.EnvVar{
+		Name:  CloudEventOverridesEnvVar,
+		Value: string(b),
+	}, true, nil
+}
+
+// withCloudEventOverridesEnv appends the K_CE_OVERRIDES env var (if any) to every container
+// in the given PodSpec.
+func withCloudEventOverridesEnv(source *sourcesv1.ContainerSource, spec *corev1.PodSpec) error {
+	env, ok, err := MakeCloudEventOverridesEnv(source)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Env = append(spec.Containers[i].Env, env)
+	}
+	return nil
+}
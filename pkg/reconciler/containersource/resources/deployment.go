@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// DeploymentName returns the name of the receive adapter Deployment for a given ContainerSource.
+func DeploymentName(source *sourcesv1.ContainerSource) string {
+	return fmt.Sprintf("%s-deployment", source.Name)
+}
+
+// MakeDeployment creates a Deployment that runs a ContainerSource's PodTemplateSpec as its
+// receive adapter.
+func MakeDeployment(source *sourcesv1.ContainerSource) (*appsv1.Deployment, error) {
+	labels := Labels(source.Name)
+
+	template := *source.Spec.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		template.Labels[k] = v
+	}
+	if err := withCloudEventOverridesEnv(source, &template.Spec); err != nil {
+		return nil, err
+	}
+	withReplicaEnv(source, &template.Spec)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            DeploymentName(source),
+			Namespace:       source.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(source)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(desiredReplicas(source)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: template,
+		},
+	}, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/kmeta"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// HPAName returns the name of the HorizontalPodAutoscaler for a given ContainerSource.
+func HPAName(source *sourcesv1.ContainerSource) string {
+	return fmt.Sprintf("%s-hpa", source.Name)
+}
+
+// MakeHPA creates a HorizontalPodAutoscaler that scales a ContainerSource's receive
+// adapter Deployment according to Spec.Autoscaling. Callers should only invoke this
+// when source.Spec.Autoscaling is set; it only applies to the default Deployment
+// adapter, since a Knative Service adapter is autoscaled by Knative Serving itself.
+func MakeHPA(source *sourcesv1.ContainerSource) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	as := source.Spec.Autoscaling
+
+	var metrics []autoscalingv2.MetricSpec
+	if as.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: as.TargetCPUUtilizationPercentage,
+				},
+			},
+		})
+	}
+	if as.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceMemory,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: as.TargetMemoryUtilizationPercentage,
+				},
+			},
+		})
+	}
+	if as.TargetMetricName != "" {
+		targetValue, err := resource.ParseQuantity(as.TargetMetricValue)
+		if err != nil {
+			return nil, fmt.Errorf("parsing targetMetricValue %q for targetMetricName %q: %w", as.TargetMetricValue, as.TargetMetricName, err)
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: as.TargetMetricName,
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &targetValue,
+				},
+			},
+		})
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            HPAName(source),
+			Namespace:       source.Namespace,
+			Labels:          Labels(source.Name),
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(source)},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       DeploymentName(source),
+			},
+			MinReplicas: as.MinReplicas,
+			MaxReplicas: as.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}, nil
+}
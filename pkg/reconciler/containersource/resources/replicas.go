@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+const (
+	// PodCountEnvVar tells a receive adapter Pod the configured replica count
+	// (Spec.Replicas, else Autoscaling.MinReplicas), so it can partition the work it
+	// pulls from its source. It does not follow replica changes made by a
+	// HorizontalPodAutoscaler.
+	PodCountEnvVar = "K_POD_COUNT"
+
+	// PodOrdinalEnvVar gives a receive adapter Pod a value to derive a stable
+	// partition index from. Unlike a StatefulSet, a Deployment's Pods have no
+	// built-in ordinal, so this carries the Pod's name via the downward API instead;
+	// the adapter is expected to hash the name (or otherwise derive a stable index
+	// from it) rather than treat the value itself as a dense 0..N-1 index.
+	PodOrdinalEnvVar = "K_POD_ORDINAL"
+)
+
+// desiredReplicas returns the receive adapter replica count a ContainerSource is
+// configured for: Spec.Replicas when set, else the autoscaler's floor, else 1.
+func desiredReplicas(source *sourcesv1.ContainerSource) int32 {
+	if source.Spec.Replicas != nil {
+		return *source.Spec.Replicas
+	}
+	if source.Spec.Autoscaling != nil && source.Spec.Autoscaling.MinReplicas != nil {
+		return *source.Spec.Autoscaling.MinReplicas
+	}
+	return 1
+}
+
+// withReplicaEnv appends K_POD_COUNT and K_POD_ORDINAL env vars to every container in
+// the given PodSpec so a multi-replica receive adapter can partition the work it pulls
+// from its source. It is a no-op when the configured replica count (see desiredReplicas)
+// is 1 or less, even if Spec.Autoscaling allows a HorizontalPodAutoscaler to scale
+// beyond it.
+func withReplicaEnv(source *sourcesv1.ContainerSource, spec *corev1.PodSpec) {
+	if desiredReplicas(source) <= 1 {
+		return
+	}
+
+	envs := []corev1.EnvVar{{
+		Name:  PodCountEnvVar,
+		Value: strconv.Itoa(int(desiredReplicas(source))),
+	}, {
+		Name: PodOrdinalEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	}}
+
+	for i := range spec.Containers {
+		spec.Containers[i].Env = append(spec.Containers[i].Env, envs...)
+	}
+}
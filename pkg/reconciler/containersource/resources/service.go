@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/kmeta"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// ServiceName returns the name of the Knative Service receive adapter for a given
+// ContainerSource, used in place of the Deployment when Spec.Adapter.Kind is
+// ContainerSourceAdapterKindKnativeService.
+func ServiceName(source *sourcesv1.ContainerSource) string {
+	return fmt.Sprintf("%s-service", source.Name)
+}
+
+// MakeService creates a Knative Service that runs a ContainerSource's PodTemplateSpec as its
+// receive adapter, giving it scale-to-zero and autoscaled replica counts.
+func MakeService(source *sourcesv1.ContainerSource) (*servingv1.Service, error) {
+	labels := Labels(source.Name)
+
+	template := *source.Spec.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		template.Labels[k] = v
+	}
+	if err := withCloudEventOverridesEnv(source, &template.Spec); err != nil {
+		return nil, err
+	}
+
+	return &servingv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ServiceName(source),
+			Namespace:       source.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(source)},
+		},
+		Spec: servingv1.ServiceSpec{
+			ConfigurationSpec: servingv1.ConfigurationSpec{
+				Template: servingv1.RevisionTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: template.Labels,
+					},
+					Spec: servingv1.RevisionSpec{
+						PodSpec: template.Spec,
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/tracker"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// SinkBindingName returns the name of the SinkBinding for a given ContainerSource.
+func SinkBindingName(source *sourcesv1.ContainerSource) string {
+	return fmt.Sprintf("%s-sinkbinding", source.Name)
+}
+
+// MakeSinkBinding creates a SinkBinding that binds a ContainerSource's receive adapter
+// to the ContainerSource's sink. The binding subject tracks the Deployment, unless
+// Spec.Adapter.Kind is ContainerSourceAdapterKindKnativeService, in which case it
+// tracks the Knative Service instead.
+func MakeSinkBinding(source *sourcesv1.ContainerSource) *sourcesv1.SinkBinding {
+	return &sourcesv1.SinkBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            SinkBindingName(source),
+			Namespace:       source.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(source)},
+		},
+		Spec: sourcesv1.SinkBindingSpec{
+			SourceSpec: source.Spec.SourceSpec,
+			BindingSpec: duckv1.BindingSpec{
+				Subject: receiveAdapterSubject(source),
+			},
+		},
+	}
+}
+
+func receiveAdapterSubject(source *sourcesv1.ContainerSource) tracker.Reference {
+	if source.Spec.IsKnativeService() {
+		return tracker.Reference{
+			APIVersion: servingv1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+			Namespace:  source.Namespace,
+			Name:       ServiceName(source),
+		}
+	}
+	return tracker.Reference{
+		APIVersion: appsv1.SchemeGroupVersion.String(),
+		Kind:       "Deployment",
+		Namespace:  source.Namespace,
+		Name:       DeploymentName(source),
+	}
+}
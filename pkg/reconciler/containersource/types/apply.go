@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// ErrUnknownSourceType is returned by Apply when Spec.SourceType doesn't name a
+// registered Flavor.
+type ErrUnknownSourceType string
+
+func (e ErrUnknownSourceType) Error() string {
+	return fmt.Sprintf("unknown source type %q", string(e))
+}
+
+// Apply resolves source's Spec.SourceType against the registry and, if set,
+// defaults and validates Spec.SourceConfig and applies the Flavor's defaults
+// onto source's Template and CloudEventOverrides in place. It is a no-op when
+// Spec.SourceType is unset. It returns ErrUnknownSourceType when SourceType
+// doesn't name a registered Flavor, and otherwise returns whatever error the
+// Flavor's own Defaulter or Validator produced.
+//
+// Apply is only called from the reconciler (see reconcileSourceType in
+// pkg/reconciler/containersource), not from an admission webhook. The
+// originating request asked for SourceConfig to be rejected at admission
+// time; this tree has no ContainerSource webhook at all (no
+// SetDefaults/Validate methods, no webhook registration, no certs/config for
+// one), so there is nothing to hang an admission-time check off of without
+// building that scaffolding from scratch, which is out of scope for this
+// series. Reconciler-only validation is the best available option here as a
+// result: a bad SourceConfig is accepted by the API server and only surfaces
+// on the SourceTypeReady condition after the fact, instead of being rejected
+// at admission time.
+func Apply(source *sourcesv1.ContainerSource) error {
+	if source.Spec.SourceType == "" {
+		return nil
+	}
+
+	f, ok := Get(source.Spec.SourceType)
+	if !ok {
+		return ErrUnknownSourceType(source.Spec.SourceType)
+	}
+
+	if f.Defaulter != nil {
+		defaulted, err := f.Defaulter(source.Spec.SourceConfig)
+		if err != nil {
+			return fmt.Errorf("defaulting sourceConfig for source type %q: %w", f.Name, err)
+		}
+		source.Spec.SourceConfig = defaulted
+	}
+
+	if f.Validator != nil {
+		if err := f.Validator(source.Spec.SourceConfig); err != nil {
+			return err
+		}
+	}
+
+	applyContainerDefaults(f, &source.Spec.Template.Spec)
+
+	if f.ExtraEnv != nil {
+		env, err := f.ExtraEnv(source.Spec.SourceConfig)
+		if err != nil {
+			return fmt.Errorf("computing extra env for source type %q: %w", f.Name, err)
+		}
+		for i := range source.Spec.Template.Spec.Containers {
+			source.Spec.Template.Spec.Containers[i].Env = append(source.Spec.Template.Spec.Containers[i].Env, env...)
+		}
+	}
+
+	if f.CloudEventOverrides != nil {
+		overrides, err := f.CloudEventOverrides(source.Spec.SourceConfig)
+		if err != nil {
+			return fmt.Errorf("computing CloudEvent overrides for source type %q: %w", f.Name, err)
+		}
+		source.Spec.CEOverrides = mergeCloudEventOverrides(overrides, source.Spec.CEOverrides)
+	}
+
+	return nil
+}
+
+// applyContainerDefaults fills in the Flavor's default image and args on spec's first
+// container, creating it if Template didn't declare one, but never overrides a value
+// the user already set.
+func applyContainerDefaults(f Flavor, spec *corev1.PodSpec) {
+	if len(spec.Containers) == 0 {
+		spec.Containers = []corev1.Container{{}}
+	}
+	c := &spec.Containers[0]
+	if c.Image == "" {
+		c.Image = f.DefaultImage
+	}
+	if len(c.Args) == 0 {
+		c.Args = f.DefaultArgs
+	}
+}
+
+// mergeCloudEventOverrides merges the Flavor's computed overrides (base) with the
+// ContainerSource's own explicit Spec.CEOverrides (override), which takes
+// precedence field-by-field.
+func mergeCloudEventOverrides(base, override *sourcesv1.ContainerSourceCloudEventOverrides) *sourcesv1.ContainerSourceCloudEventOverrides {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *base
+	if override.Source != "" {
+		merged.Source = override.Source
+	}
+	if override.Type != "" {
+		merged.Type = override.Type
+	}
+	if len(override.Extensions) > 0 {
+		extensions := make(map[string]string, len(merged.Extensions)+len(override.Extensions))
+		for k, v := range merged.Extensions {
+			extensions[k] = v
+		}
+		for k, v := range override.Extensions {
+			extensions[k] = v
+		}
+		merged.Extensions = extensions
+	}
+	return &merged
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package types implements a registry of pluggable source "flavors" that let a
+// ContainerSource host a typed source (e.g. a CloudBuildSource-style source)
+// without requiring a dedicated CRD of its own. Third parties Register a
+// Flavor, typically from their own package's init(); setting Spec.SourceType
+// to a registered Flavor's Name opts a ContainerSource into it.
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// Flavor describes a named source type that a ContainerSource can host.
+type Flavor struct {
+	// Name identifies the Flavor. It is the value ContainerSources set in
+	// Spec.SourceType to opt into it.
+	Name string
+
+	// DefaultImage is the container image used for the receive adapter's first
+	// container when the ContainerSource's PodTemplateSpec doesn't specify one.
+	DefaultImage string
+
+	// DefaultArgs are the default container args for the receive adapter's first
+	// container, used when it doesn't specify any of its own.
+	DefaultArgs []string
+
+	// Defaulter returns a defaulted copy of Spec.SourceConfig. It may be nil, in
+	// which case Spec.SourceConfig is used as-is.
+	Defaulter func(config *runtime.RawExtension) (*runtime.RawExtension, error)
+
+	// Validator validates the (defaulted) Spec.SourceConfig. Its error is
+	// surfaced verbatim onto the ContainerSource's SourceTypeReady condition. It
+	// may be nil, in which case Spec.SourceConfig is accepted unconditionally.
+	Validator func(config *runtime.RawExtension) error
+
+	// CloudEventOverrides computes CloudEvent context overrides from Spec.SourceConfig.
+	// They are applied as a base that the ContainerSource's own Spec.CEOverrides,
+	// when also set, takes precedence over. It may be nil.
+	CloudEventOverrides func(config *runtime.RawExtension) (*sourcesv1.ContainerSourceCloudEventOverrides, error)
+
+	// ExtraEnv returns additional env vars to inject into the receive adapter,
+	// typically to pass the Flavor's config or credentials through to it. It may
+	// be nil.
+	ExtraEnv func(config *runtime.RawExtension) ([]corev1.EnvVar, error)
+}
+
+var (
+	mu      sync.RWMutex
+	flavors = map[string]Flavor{}
+)
+
+// Register adds a Flavor to the registry under f.Name. It panics if a Flavor is
+// already registered under that name, since that indicates two packages are
+// fighting over the same source type at init() time.
+func Register(f Flavor) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := flavors[f.Name]; ok {
+		panic(fmt.Sprintf("types: flavor %q already registered", f.Name))
+	}
+	flavors[f.Name] = f
+}
+
+// Get returns the Flavor registered under name, if any.
+func Get(name string) (Flavor, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	f, ok := flavors[name]
+	return f, ok
+}
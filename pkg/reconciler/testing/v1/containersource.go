@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// ContainerSourceOption enables further configuration of a ContainerSource.
+type ContainerSourceOption func(*sourcesv1.ContainerSource)
+
+// NewContainerSource creates a ContainerSource with ContainerSourceOptions.
+func NewContainerSource(name, namespace string, o ...ContainerSourceOption) *sourcesv1.ContainerSource {
+	c := &sourcesv1.ContainerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, opt := range o {
+		opt(c)
+	}
+	return c
+}
+
+// WithContainerSourceUID sets the ContainerSource's UID.
+func WithContainerSourceUID(uid string) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.UID = types.UID(uid)
+	}
+}
+
+// WithContainerSourceSpec sets the ContainerSource's spec.
+func WithContainerSourceSpec(spec sourcesv1.ContainerSourceSpec) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Spec = spec
+	}
+}
+
+// WithContainerSourceObjectMetaGeneration sets the ContainerSource's ObjectMeta.Generation.
+func WithContainerSourceObjectMetaGeneration(generation int64) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.ObjectMeta.Generation = generation
+	}
+}
+
+// WithInitContainerSourceConditions initializes the ContainerSource's conditions.
+func WithInitContainerSourceConditions(c *sourcesv1.ContainerSource) {
+	c.Status.InitializeConditions()
+}
+
+// WithContainerSourceStatusObservedGeneration sets the ContainerSource's observed generation.
+func WithContainerSourceStatusObservedGeneration(generation int64) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.ObservedGeneration = generation
+	}
+}
+
+// WithContainerUnobservedGeneration marks the ContainerSource's top-level condition Unknown
+// with reason NewObservedGenFailure, as it is left when the reconciler observes a new
+// generation but returns before marking any condition.
+func WithContainerUnobservedGeneration() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		condSet := c.GetConditionSet()
+		condSet.Manage(&c.Status).MarkUnknown(
+			condSet.GetTopLevelConditionType(), "NewObservedGenFailure", "unsuccessfully observed a new generation")
+	}
+}
+
+// WithContainerSourcePropagateSinkbindingStatus propagates the SinkBinding's status onto the ContainerSource.
+func WithContainerSourcePropagateSinkbindingStatus(sbs *sourcesv1.SinkBindingStatus) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.PropagateSinkbindingStatus(sbs)
+	}
+}
+
+// WithContainerSourcePropagateReceiveAdapterStatus propagates the receive adapter Deployment's
+// status onto the ContainerSource.
+func WithContainerSourcePropagateReceiveAdapterStatus(d *appsv1.Deployment) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.PropagateReceiveAdapterStatus(d)
+	}
+}
+
+// WithContainerSourcePropagateServiceStatus propagates a Knative Service receive adapter's
+// status onto the ContainerSource.
+func WithContainerSourcePropagateServiceStatus(ksvc *servingv1.Service) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.PropagateServiceStatus(ksvc)
+	}
+}
+
+// WithContainerSourceOIDCIdentityCreatedSucceeded marks the OIDCIdentityCreated condition True.
+func WithContainerSourceOIDCIdentityCreatedSucceeded() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkOIDCIdentityCreatedSucceeded()
+	}
+}
+
+// WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled marks the
+// OIDCIdentityCreated condition True because the OIDC feature is disabled.
+func WithContainerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled()
+	}
+}
+
+// WithContainerSourceOIDCIdentityCreatedFailed marks the OIDCIdentityCreated condition False.
+func WithContainerSourceOIDCIdentityCreatedFailed(reason, messageFormat string) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkOIDCIdentityCreatedFailed(reason, messageFormat)
+	}
+}
+
+// WithContainerSourceCloudEventOverridesApplied marks the CloudEventOverridesApplied condition True.
+func WithContainerSourceCloudEventOverridesApplied() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkCloudEventOverridesApplied()
+	}
+}
+
+// WithContainerSourcePropagateHPAStatus propagates a HorizontalPodAutoscaler's status onto
+// the ContainerSource's HPAReady condition.
+func WithContainerSourcePropagateHPAStatus(hpa *autoscalingv2.HorizontalPodAutoscaler) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.PropagateHPAStatus(hpa)
+	}
+}
+
+// WithContainerSourceHPANotRequested marks the HPAReady condition True because no
+// autoscaling was requested.
+func WithContainerSourceHPANotRequested() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkHPANotRequested()
+	}
+}
+
+// WithContainerSourceOIDCServiceAccountName sets the name of the ServiceAccount backing the
+// ContainerSource's OIDC identity.
+func WithContainerSourceOIDCServiceAccountName(name string) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.OIDCServiceAccountName = name
+	}
+}
+
+// WithContainerSourceSourceTypeNotRequested marks the SourceTypeReady condition True because
+// no SourceType was requested.
+func WithContainerSourceSourceTypeNotRequested() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkSourceTypeNotRequested()
+	}
+}
+
+// WithContainerSourceSourceTypeReady marks the SourceTypeReady condition True.
+func WithContainerSourceSourceTypeReady() ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkSourceTypeReady()
+	}
+}
+
+// WithContainerSourceSourceTypeUnknown marks the SourceTypeReady condition False because
+// Spec.SourceType doesn't name a registered flavor.
+func WithContainerSourceSourceTypeUnknown(message string) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkSourceTypeUnknown(message)
+	}
+}
+
+// WithContainerSourceSourceTypeInvalid marks the SourceTypeReady condition False because the
+// flavor rejected Spec.SourceConfig.
+func WithContainerSourceSourceTypeInvalid(message string) ContainerSourceOption {
+	return func(c *sourcesv1.ContainerSource) {
+		c.Status.MarkSourceTypeInvalid(message)
+	}
+}